@@ -0,0 +1,60 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/internal/auth"
+	"github.com/harness/gitness/internal/webhook/format"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RepositoryForkedEvent holds the details of a repository fork. It is not itself
+// sent to webhooks: publishRepositoryForkedEvent renders its fields into the
+// format.Event that is dispatched for enum.WebhookTriggerFork.
+type RepositoryForkedEvent struct {
+	ParentID int64  `json:"parent_id"`
+	ForkID   int64  `json:"fork_id"`
+	ForkPath string `json:"fork_path"`
+	ActorID  int64  `json:"actor_id"`
+	Created  int64  `json:"created"`
+}
+
+// publishRepositoryForkedEvent dispatches enum.WebhookTriggerFork webhooks
+// registered on the parent repository for a fork that has been created.
+// The repo controller has no Fork action, and nothing calls this function.
+func (c *Controller) publishRepositoryForkedEvent(
+	ctx context.Context,
+	session *auth.Session,
+	parent *types.Repository,
+	fork *types.Repository,
+) error {
+	event := RepositoryForkedEvent{
+		ParentID: parent.ID,
+		ForkID:   fork.ID,
+		ForkPath: fork.Path,
+		ActorID:  session.Principal.ID,
+		Created:  time.Now().UnixMilli(),
+	}
+
+	err := c.webhookService.DispatchRepositoryEvent(ctx, enum.WebhookParentRepo, event.ParentID, &format.Event{
+		Trigger:     enum.WebhookTriggerFork,
+		RepoName:    parent.Path,
+		RepoURL:     c.urlProvider.GenerateRepoCloneURL(parent.Path),
+		Title:       fmt.Sprintf("Repository forked to '%s'", event.ForkPath),
+		Description: fmt.Sprintf("%s forked '%s' to '%s'", session.Principal.DisplayName, parent.Path, event.ForkPath),
+		ActorName:   session.Principal.DisplayName,
+		URL:         c.urlProvider.GenerateRepoCloneURL(event.ForkPath),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to dispatch repository forked event: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+// Package webhook provides the webhook management API - creating, updating
+// and inspecting webhooks and their delivery history.
+package webhook
+
+import (
+	"github.com/harness/gitness/internal/auth/authz"
+	"github.com/harness/gitness/internal/store"
+)
+
+// Controller is used to manage webhooks and their deliveries.
+type Controller struct {
+	authorizer    authz.Authorizer
+	webhookStore  store.WebhookStore
+	hookTaskStore store.HookTaskStore
+	repoStore     store.RepoStore
+	spaceStore    store.SpaceStore
+}
+
+// NewController returns a new Controller.
+func NewController(
+	authorizer authz.Authorizer,
+	webhookStore store.WebhookStore,
+	hookTaskStore store.HookTaskStore,
+	repoStore store.RepoStore,
+	spaceStore store.SpaceStore,
+) *Controller {
+	return &Controller{
+		authorizer:    authorizer,
+		webhookStore:  webhookStore,
+		hookTaskStore: hookTaskStore,
+		repoStore:     repoStore,
+		spaceStore:    spaceStore,
+	}
+}
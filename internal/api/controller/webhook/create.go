@@ -0,0 +1,111 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/internal/api/auth"
+	"github.com/harness/gitness/internal/auth"
+	"github.com/harness/gitness/internal/webhook/format"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// CreateInput holds the fields that can be set when creating a webhook.
+type CreateInput struct {
+	URL                 string                `json:"url"`
+	Secret              string                `json:"secret"`
+	Enabled             bool                  `json:"enabled"`
+	Insecure            bool                  `json:"insecure"`
+	Triggers            []enum.WebhookTrigger `json:"triggers"`
+	Format              enum.WebhookFormat    `json:"format"`
+	AuthorizationHeader string                `json:"authorization_header"`
+	AllowPrivate        bool                  `json:"allow_private"`
+}
+
+// Create creates a new webhook on the given parent.
+func (c *Controller) Create(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentID int64,
+	in *CreateInput,
+) (*types.Webhook, error) {
+	if err := c.checkParentAccess(ctx, session, parentType, parentID, enum.PermissionRepoEdit); err != nil {
+		return nil, err
+	}
+
+	if in.AllowPrivate && !session.Principal.Admin {
+		return nil, fmt.Errorf("only admins can allow webhooks to target private addresses")
+	}
+
+	hookFormat := in.Format
+	if hookFormat == "" {
+		hookFormat = enum.WebhookFormatGeneric
+	}
+	if _, err := format.Get(hookFormat); err != nil {
+		return nil, fmt.Errorf("invalid webhook format: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	hook := &types.Webhook{
+		ParentType:          parentType,
+		ParentID:            parentID,
+		CreatedBy:           session.Principal.ID,
+		Created:             now,
+		Updated:             now,
+		URL:                 in.URL,
+		Secret:              in.Secret,
+		Enabled:             in.Enabled,
+		Insecure:            in.Insecure,
+		Triggers:            in.Triggers,
+		Format:              hookFormat,
+		AuthorizationHeader: in.AuthorizationHeader,
+		AllowPrivate:        in.AllowPrivate,
+	}
+
+	if err := c.webhookStore.Create(ctx, hook); err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+	hook.HasAuthorizationHeader = hook.AuthorizationHeader != ""
+
+	return hook, nil
+}
+
+// checkParentAccess verifies the session has the requested permission on a
+// repo/space, independent of any particular webhook.
+func (c *Controller) checkParentAccess(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentID int64,
+	permission enum.Permission,
+) error {
+	switch parentType {
+	case enum.WebhookParentRepo:
+		repo, err := c.repoStore.Find(ctx, parentID)
+		if err != nil {
+			return fmt.Errorf("failed to find repo: %w", err)
+		}
+		if err = apiauth.CheckRepo(ctx, c.authorizer, session, repo, permission, false); err != nil {
+			return fmt.Errorf("access check failed: %w", err)
+		}
+	case enum.WebhookParentSpace:
+		space, err := c.spaceStore.Find(ctx, parentID)
+		if err != nil {
+			return fmt.Errorf("failed to find space: %w", err)
+		}
+		if err = apiauth.CheckSpace(ctx, c.authorizer, session, space, permission, false); err != nil {
+			return fmt.Errorf("access check failed: %w", err)
+		}
+	default:
+		return fmt.Errorf("webhook parent type '%s' is not supported", parentType)
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/internal/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Delete deletes a webhook.
+func (c *Controller) Delete(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentID int64,
+	webhookID int64,
+) error {
+	if _, err := c.checkWebhookAccess(ctx, session, parentType, parentID, webhookID, enum.PermissionRepoEdit); err != nil {
+		return err
+	}
+
+	if err := c.webhookStore.Delete(ctx, webhookID); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/internal/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListHookTasks lists the delivery history (past and pending attempts) for a webhook.
+func (c *Controller) ListHookTasks(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentID int64,
+	webhookID int64,
+	filter *types.HookTaskFilter,
+) ([]*types.HookTask, int64, error) {
+	hook, err := c.checkWebhookAccess(ctx, session, parentType, parentID, webhookID, enum.PermissionRepoView)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.hookTaskStore.Count(ctx, hook.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count hook tasks: %w", err)
+	}
+
+	tasks, err := c.hookTaskStore.List(ctx, hook.ID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list hook tasks: %w", err)
+	}
+
+	return tasks, count, nil
+}
+
+// checkWebhookAccess loads the webhook and verifies the session has the requested
+// permission on its parent (repo or space).
+func (c *Controller) checkWebhookAccess(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentID int64,
+	webhookID int64,
+	permission enum.Permission,
+) (*types.Webhook, error) {
+	hook, err := c.webhookStore.Find(ctx, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook: %w", err)
+	}
+
+	if hook.ParentType != parentType || hook.ParentID != parentID {
+		return nil, fmt.Errorf("webhook %d does not belong to %s %d", webhookID, parentType, parentID)
+	}
+
+	if err = c.checkParentAccess(ctx, session, parentType, parentID, permission); err != nil {
+		return nil, err
+	}
+
+	return hook, nil
+}
@@ -0,0 +1,50 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/internal/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// List lists the webhooks configured on a repo or space.
+func (c *Controller) List(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentID int64,
+	filter *types.WebhookFilter,
+) ([]*types.Webhook, int64, error) {
+	if err := c.checkParentAccess(ctx, session, parentType, parentID, enum.PermissionRepoView); err != nil {
+		return nil, 0, err
+	}
+
+	count, err := c.webhookStore.Count(ctx, parentType, parentID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhooks: %w", err)
+	}
+
+	hooks, err := c.webhookStore.List(ctx, parentType, parentID, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return hooks, count, nil
+}
+
+// Find finds a single webhook by id, verifying it belongs to the given parent.
+func (c *Controller) Find(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentID int64,
+	webhookID int64,
+) (*types.Webhook, error) {
+	return c.checkWebhookAccess(ctx, session, parentType, parentID, webhookID, enum.PermissionRepoView)
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/internal/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Redeliver schedules an immediate retry of a previously attempted hook task,
+// copying its request over into a new pending delivery.
+func (c *Controller) Redeliver(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentID int64,
+	webhookID int64,
+	hookTaskID int64,
+) (*types.HookTask, error) {
+	hook, err := c.checkWebhookAccess(ctx, session, parentType, parentID, webhookID, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	original, err := c.hookTaskStore.Find(ctx, hookTaskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find hook task: %w", err)
+	}
+	if original.WebhookID != hook.ID {
+		return nil, fmt.Errorf("hook task %d does not belong to webhook %d", hookTaskID, hook.ID)
+	}
+
+	now := time.Now().UnixMilli()
+	task := &types.HookTask{
+		WebhookID:       hook.ID,
+		Created:         now,
+		Updated:         now,
+		Trigger:         original.Trigger,
+		RequestURL:      original.RequestURL,
+		RequestHeaders:  original.RequestHeaders,
+		RequestBody:     original.RequestBody,
+		Attempts:        0,
+		IsDelivered:     false,
+		NextDeliveredAt: now,
+	}
+
+	if err = c.hookTaskStore.Create(ctx, task); err != nil {
+		return nil, fmt.Errorf("failed to create redelivery hook task: %w", err)
+	}
+
+	return task, nil
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/internal/auth"
+	"github.com/harness/gitness/internal/webhook/format"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateInput holds the fields that can be changed on an existing webhook.
+// Nil fields are left unchanged.
+type UpdateInput struct {
+	URL                 *string                `json:"url"`
+	Secret              *string                `json:"secret"`
+	Enabled             *bool                  `json:"enabled"`
+	Insecure            *bool                  `json:"insecure"`
+	Triggers            *[]enum.WebhookTrigger `json:"triggers"`
+	Format              *enum.WebhookFormat    `json:"format"`
+	AuthorizationHeader *string                `json:"authorization_header"`
+	AllowPrivate        *bool                  `json:"allow_private"`
+}
+
+// Update updates an existing webhook.
+func (c *Controller) Update(
+	ctx context.Context,
+	session *auth.Session,
+	parentType enum.WebhookParent,
+	parentID int64,
+	webhookID int64,
+	in *UpdateInput,
+) (*types.Webhook, error) {
+	hook, err := c.checkWebhookAccess(ctx, session, parentType, parentID, webhookID, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if in.AllowPrivate != nil && *in.AllowPrivate && !session.Principal.Admin {
+		return nil, fmt.Errorf("only admins can allow webhooks to target private addresses")
+	}
+
+	if in.URL != nil {
+		hook.URL = *in.URL
+	}
+	if in.Secret != nil {
+		hook.Secret = *in.Secret
+	}
+	if in.Enabled != nil {
+		hook.Enabled = *in.Enabled
+	}
+	if in.Insecure != nil {
+		hook.Insecure = *in.Insecure
+	}
+	if in.Triggers != nil {
+		hook.Triggers = *in.Triggers
+	}
+	if in.Format != nil {
+		if _, err := format.Get(*in.Format); err != nil {
+			return nil, fmt.Errorf("invalid webhook format: %w", err)
+		}
+		hook.Format = *in.Format
+	}
+	if in.AuthorizationHeader != nil {
+		hook.AuthorizationHeader = *in.AuthorizationHeader
+	}
+	if in.AllowPrivate != nil {
+		hook.AllowPrivate = *in.AllowPrivate
+	}
+
+	if err = c.webhookStore.Update(ctx, hook); err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+	hook.HasAuthorizationHeader = hook.AuthorizationHeader != ""
+
+	return hook, nil
+}
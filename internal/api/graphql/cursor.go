@@ -0,0 +1,74 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package graphql
+
+import (
+	"encoding/base64"
+	"fmt"
+)
+
+// defaultPageSize is used when a query doesn't specify `first`.
+const defaultPageSize = 25
+
+// page turns Relay-style (first, after) pagination args into the page/size pair
+// the REST API's types.WebhookFilter/types.HookTaskFilter already use, so the
+// GraphQL layer doesn't need its own offset bookkeeping in the store.
+func page(first *int32, after *string) (pageNum, size int, err error) {
+	size = defaultPageSize
+	if first != nil {
+		size = int(*first)
+	}
+	if size <= 0 {
+		return 0, 0, fmt.Errorf("first must be greater than 0, got %d", size)
+	}
+
+	offset := 0
+	if after != nil {
+		offset, err = decodeCursor(*after)
+		if err != nil {
+			return 0, 0, err
+		}
+		// the cursor identifies the last row already seen, so the page starts after it.
+		offset++
+	}
+
+	// types.WebhookFilter/types.HookTaskFilter paginate by (1-indexed) page number,
+	// so translate the cursor's absolute offset back into one.
+	return offset/size + 1, size, nil
+}
+
+// cursorForIndex returns the opaque cursor identifying the row at absolute index i.
+func cursorForIndex(i int) string {
+	return encodeCursor(i)
+}
+
+// hasNextPage reports whether more rows exist after the ones returned in this page.
+func hasNextPage(offset, returned int, totalCount int64) bool {
+	return int64(offset+returned) < totalCount
+}
+
+func encodeCursor(offset int) string {
+	return base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("offset:%d", offset)))
+}
+
+func decodeCursor(cursor string) (int, error) {
+	raw, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var offset int
+	if _, err := fmt.Sscanf(string(raw), "offset:%d", &offset); err != nil {
+		return 0, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return offset, nil
+}
+
+// offsetOf recovers the absolute offset a (pageNum, size) pair started at -
+// the inverse of the division in page().
+func offsetOf(pageNum, size int) int {
+	return (pageNum - 1) * size
+}
@@ -0,0 +1,56 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package graphql
+
+import (
+	"context"
+
+	"github.com/harness/gitness/internal/webhook"
+	"github.com/harness/gitness/types"
+)
+
+// deliveryBroadcaster adapts the single, unfiltered webhook.Service.Subscribe
+// feed into per-webhook feeds, so the webhookDelivery subscription only has to
+// deal with the one webhook it was asked about.
+type deliveryBroadcaster struct {
+	service *webhook.Service
+}
+
+func newDeliveryBroadcaster(service *webhook.Service) *deliveryBroadcaster {
+	return &deliveryBroadcaster{service: service}
+}
+
+// subscribe returns a channel of hook tasks delivered for webhookID, and an
+// unsubscribe func that must be called once the caller stops listening.
+// The forwarding goroutine also selects on ctx.Done(), so it can't block
+// forever sending to filtered after the caller has already walked away.
+func (b *deliveryBroadcaster) subscribe(ctx context.Context, webhookID int64) (<-chan *types.HookTask, func()) {
+	tasks, unsubscribe := b.service.Subscribe()
+
+	filtered := make(chan *types.HookTask)
+	go func() {
+		defer close(filtered)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case task, ok := <-tasks:
+				if !ok {
+					return
+				}
+				if task.WebhookID != webhookID {
+					continue
+				}
+				select {
+				case filtered <- task:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return filtered, unsubscribe
+}
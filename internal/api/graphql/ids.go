@@ -0,0 +1,25 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package graphql
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// idToString renders an internal int64 id as the opaque string the GraphQL ID
+// scalar expects.
+func idToString(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
+
+// idFromString parses a GraphQL ID scalar back into an internal int64 id.
+func idFromString(id string) (int64, error) {
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid id %q: %w", id, err)
+	}
+	return n, nil
+}
@@ -0,0 +1,209 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	webhookctrl "github.com/harness/gitness/internal/api/controller/webhook"
+	"github.com/harness/gitness/internal/api/request"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+func triggersFromStrings(in []string) []enum.WebhookTrigger {
+	triggers := make([]enum.WebhookTrigger, len(in))
+	for i, t := range in {
+		triggers[i] = enum.WebhookTrigger(t)
+	}
+	return triggers
+}
+
+type createWebhookInput struct {
+	ParentType          string
+	ParentId            graphql.ID
+	Url                 string
+	Secret              *string
+	Enabled             *bool
+	Insecure            *bool
+	Triggers            *[]string
+	Format              *string
+	AuthorizationHeader *string
+	AllowPrivate        *bool
+}
+
+type createWebhookArgs struct {
+	Input createWebhookInput
+}
+
+// CreateWebhook resolves the Mutation.createWebhook field.
+func (r *Resolver) CreateWebhook(ctx context.Context, args createWebhookArgs) (*webhookResolver, error) {
+	session, err := request.AuthSessionFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID, err := idFromString(string(args.Input.ParentId))
+	if err != nil {
+		return nil, err
+	}
+
+	in := &webhookctrl.CreateInput{URL: args.Input.Url}
+	if args.Input.Secret != nil {
+		in.Secret = *args.Input.Secret
+	}
+	if args.Input.Enabled != nil {
+		in.Enabled = *args.Input.Enabled
+	}
+	if args.Input.Insecure != nil {
+		in.Insecure = *args.Input.Insecure
+	}
+	if args.Input.Triggers != nil {
+		in.Triggers = triggersFromStrings(*args.Input.Triggers)
+	}
+	if args.Input.Format != nil {
+		in.Format = enum.WebhookFormat(*args.Input.Format)
+	}
+	if args.Input.AuthorizationHeader != nil {
+		in.AuthorizationHeader = *args.Input.AuthorizationHeader
+	}
+	if args.Input.AllowPrivate != nil {
+		in.AllowPrivate = *args.Input.AllowPrivate
+	}
+
+	hook, err := r.controller.Create(ctx, session, enum.WebhookParent(args.Input.ParentType), parentID, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return &webhookResolver{hook: hook}, nil
+}
+
+type updateWebhookInput struct {
+	Url                 *string
+	Secret              *string
+	Enabled             *bool
+	Insecure            *bool
+	Triggers            *[]string
+	Format              *string
+	AuthorizationHeader *string
+	AllowPrivate        *bool
+}
+
+type updateWebhookArgs struct {
+	ParentType string
+	ParentId   graphql.ID
+	ID         graphql.ID
+	Input      updateWebhookInput
+}
+
+// UpdateWebhook resolves the Mutation.updateWebhook field.
+func (r *Resolver) UpdateWebhook(ctx context.Context, args updateWebhookArgs) (*webhookResolver, error) {
+	session, err := request.AuthSessionFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID, err := idFromString(string(args.ParentId))
+	if err != nil {
+		return nil, err
+	}
+	webhookID, err := idFromString(string(args.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	in := &webhookctrl.UpdateInput{
+		URL:                 args.Input.Url,
+		Secret:              args.Input.Secret,
+		Enabled:             args.Input.Enabled,
+		Insecure:            args.Input.Insecure,
+		AuthorizationHeader: args.Input.AuthorizationHeader,
+		AllowPrivate:        args.Input.AllowPrivate,
+	}
+	if args.Input.Triggers != nil {
+		triggers := triggersFromStrings(*args.Input.Triggers)
+		in.Triggers = &triggers
+	}
+	if args.Input.Format != nil {
+		format := enum.WebhookFormat(*args.Input.Format)
+		in.Format = &format
+	}
+
+	hook, err := r.controller.Update(ctx, session, enum.WebhookParent(args.ParentType), parentID, webhookID, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update webhook: %w", err)
+	}
+
+	return &webhookResolver{hook: hook}, nil
+}
+
+type deleteWebhookArgs struct {
+	ParentType string
+	ParentId   graphql.ID
+	ID         graphql.ID
+}
+
+// DeleteWebhook resolves the Mutation.deleteWebhook field.
+func (r *Resolver) DeleteWebhook(ctx context.Context, args deleteWebhookArgs) (bool, error) {
+	session, err := request.AuthSessionFrom(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	parentID, err := idFromString(string(args.ParentId))
+	if err != nil {
+		return false, err
+	}
+	webhookID, err := idFromString(string(args.ID))
+	if err != nil {
+		return false, err
+	}
+
+	if err := r.controller.Delete(ctx, session, enum.WebhookParent(args.ParentType), parentID, webhookID); err != nil {
+		return false, fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return true, nil
+}
+
+type redeliverHookArgs struct {
+	ParentType string
+	ParentId   graphql.ID
+	WebhookId  graphql.ID
+	HookTaskId graphql.ID
+}
+
+// RedeliverHook resolves the Mutation.redeliverHook field.
+func (r *Resolver) RedeliverHook(ctx context.Context, args redeliverHookArgs) (*hookDeliveryResolver, error) {
+	session, err := request.AuthSessionFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID, err := idFromString(string(args.ParentId))
+	if err != nil {
+		return nil, err
+	}
+	webhookID, err := idFromString(string(args.WebhookId))
+	if err != nil {
+		return nil, err
+	}
+	hookTaskID, err := idFromString(string(args.HookTaskId))
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := r.controller.Redeliver(
+		ctx, session, enum.WebhookParent(args.ParentType), parentID, webhookID, hookTaskID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeliver hook task: %w", err)
+	}
+
+	return &hookDeliveryResolver{task: task}, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/internal/api/request"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+type webhookArgs struct {
+	ParentType string
+	ParentId   graphql.ID
+	ID         graphql.ID
+}
+
+// Webhook resolves the Query.webhook field.
+func (r *Resolver) Webhook(ctx context.Context, args webhookArgs) (*webhookResolver, error) {
+	session, err := request.AuthSessionFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID, err := idFromString(string(args.ParentId))
+	if err != nil {
+		return nil, err
+	}
+	webhookID, err := idFromString(string(args.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	hook, err := r.controller.Find(ctx, session, enum.WebhookParent(args.ParentType), parentID, webhookID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook: %w", err)
+	}
+
+	return &webhookResolver{hook: hook}, nil
+}
+
+type webhooksArgs struct {
+	ParentType string
+	ParentId   graphql.ID
+	First      *int32
+	After      *string
+}
+
+// Webhooks resolves the Query.webhooks field.
+func (r *Resolver) Webhooks(ctx context.Context, args webhooksArgs) (*webhookConnectionResolver, error) {
+	session, err := request.AuthSessionFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID, err := idFromString(string(args.ParentId))
+	if err != nil {
+		return nil, err
+	}
+
+	pageNum, size, err := page(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	hooks, count, err := r.controller.List(
+		ctx, session, enum.WebhookParent(args.ParentType), parentID,
+		&types.WebhookFilter{Page: pageNum, Size: size},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	return &webhookConnectionResolver{hooks: hooks, totalCount: count, offset: offsetOf(pageNum, size)}, nil
+}
+
+type hookDeliveriesArgs struct {
+	ParentType string
+	ParentId   graphql.ID
+	WebhookId  graphql.ID
+	First      *int32
+	After      *string
+}
+
+// HookDeliveries resolves the Query.hookDeliveries field.
+func (r *Resolver) HookDeliveries(ctx context.Context, args hookDeliveriesArgs) (*hookDeliveryConnectionResolver, error) {
+	session, err := request.AuthSessionFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID, err := idFromString(string(args.ParentId))
+	if err != nil {
+		return nil, err
+	}
+	webhookID, err := idFromString(string(args.WebhookId))
+	if err != nil {
+		return nil, err
+	}
+
+	pageNum, size, err := page(args.First, args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, count, err := r.controller.ListHookTasks(
+		ctx, session, enum.WebhookParent(args.ParentType), parentID, webhookID,
+		&types.HookTaskFilter{Page: pageNum, Size: size},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list hook deliveries: %w", err)
+	}
+
+	return &hookDeliveryConnectionResolver{tasks: tasks, totalCount: count, offset: offsetOf(pageNum, size)}, nil
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+// Package graphql exposes webhooks and their delivery history as a typed GraphQL
+// schema, so clients can build dashboards of webhook activity (including a
+// websocket subscription for new deliveries) without polling the REST API.
+package graphql
+
+import (
+	_ "embed"
+	"fmt"
+
+	webhookctrl "github.com/harness/gitness/internal/api/controller/webhook"
+	"github.com/harness/gitness/internal/webhook"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// Resolver is the GraphQL root resolver. Every query/mutation/subscription
+// resolver delegates to the webhook Controller, reusing its store access,
+// authorization checks, and input validation rather than duplicating them.
+type Resolver struct {
+	controller *webhookctrl.Controller
+	deliveries *deliveryBroadcaster
+}
+
+// NewSchema parses the embedded SDL and binds it to a new Resolver.
+// deliveries receives a broadcast whenever the delivery worker (internal/webhook)
+// attempts a delivery, so the webhookDelivery subscription can stream it out.
+func NewSchema(controller *webhookctrl.Controller, deliveries *webhook.Service) (*graphql.Schema, error) {
+	resolver := &Resolver{
+		controller: controller,
+		deliveries: newDeliveryBroadcaster(deliveries),
+	}
+
+	schema, err := graphql.ParseSchema(schemaSDL, resolver)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse graphql schema: %w", err)
+	}
+
+	return schema, nil
+}
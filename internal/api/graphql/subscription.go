@@ -0,0 +1,73 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package graphql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/internal/api/request"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+type webhookDeliveryArgs struct {
+	ParentType string
+	ParentId   graphql.ID
+	WebhookId  graphql.ID
+}
+
+// WebhookDelivery resolves the Subscription.webhookDelivery field, streaming
+// the hook tasks the delivery worker attempts for the given webhook until the
+// client disconnects. Tasks that webhook.Service drops for a full subscriber
+// buffer are not streamed.
+func (r *Resolver) WebhookDelivery(ctx context.Context, args webhookDeliveryArgs) (<-chan *hookDeliveryResolver, error) {
+	session, err := request.AuthSessionFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID, err := idFromString(string(args.ParentId))
+	if err != nil {
+		return nil, err
+	}
+	webhookID, err := idFromString(string(args.WebhookId))
+	if err != nil {
+		return nil, err
+	}
+
+	// Find both verifies the webhook belongs to this parent and that the
+	// session has view access to it, before any deliveries are streamed out.
+	if _, err = r.controller.Find(ctx, session, enum.WebhookParent(args.ParentType), parentID, webhookID); err != nil {
+		return nil, fmt.Errorf("failed to find webhook: %w", err)
+	}
+
+	tasks, unsubscribe := r.deliveries.subscribe(ctx, webhookID)
+	out := make(chan *hookDeliveryResolver)
+
+	go func() {
+		defer close(out)
+		defer unsubscribe()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case task, ok := <-tasks:
+				if !ok {
+					return
+				}
+				select {
+				case out <- &hookDeliveryResolver{task: task}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
@@ -0,0 +1,139 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package graphql
+
+import (
+	"github.com/harness/gitness/types"
+
+	"github.com/graph-gophers/graphql-go"
+)
+
+// webhookResolver adapts a types.Webhook to the schema's Webhook type.
+type webhookResolver struct {
+	hook *types.Webhook
+}
+
+func (r *webhookResolver) ID() graphql.ID        { return graphql.ID(idToString(r.hook.ID)) }
+func (r *webhookResolver) ParentType() string    { return string(r.hook.ParentType) }
+func (r *webhookResolver) ParentId() graphql.ID  { return graphql.ID(idToString(r.hook.ParentID)) }
+func (r *webhookResolver) Url() string           { return r.hook.URL }
+func (r *webhookResolver) Enabled() bool         { return r.hook.Enabled }
+func (r *webhookResolver) Insecure() bool        { return r.hook.Insecure }
+func (r *webhookResolver) Format() string        { return string(r.hook.Format) }
+func (r *webhookResolver) HasSecretHeader() bool { return r.hook.HasAuthorizationHeader }
+func (r *webhookResolver) AllowPrivate() bool    { return r.hook.AllowPrivate }
+func (r *webhookResolver) Created() float64      { return float64(r.hook.Created) }
+func (r *webhookResolver) Updated() float64      { return float64(r.hook.Updated) }
+
+func (r *webhookResolver) Triggers() []string {
+	triggers := make([]string, len(r.hook.Triggers))
+	for i, t := range r.hook.Triggers {
+		triggers[i] = string(t)
+	}
+	return triggers
+}
+
+// webhookEdgeResolver adapts a single row of a webhook page to the schema's
+// Relay-style WebhookEdge type.
+type webhookEdgeResolver struct {
+	hook   *types.Webhook
+	cursor string
+}
+
+func (r *webhookEdgeResolver) Cursor() string         { return r.cursor }
+func (r *webhookEdgeResolver) Node() *webhookResolver { return &webhookResolver{hook: r.hook} }
+
+// webhookConnectionResolver adapts a page of webhooks, plus the total count
+// needed to compute pageInfo, to the schema's WebhookConnection type.
+type webhookConnectionResolver struct {
+	hooks      []*types.Webhook
+	totalCount int64
+	offset     int
+}
+
+func (r *webhookConnectionResolver) TotalCount() int32 { return int32(r.totalCount) }
+
+func (r *webhookConnectionResolver) Edges() []*webhookEdgeResolver {
+	edges := make([]*webhookEdgeResolver, len(r.hooks))
+	for i, hook := range r.hooks {
+		edges[i] = &webhookEdgeResolver{hook: hook, cursor: cursorForIndex(r.offset + i)}
+	}
+	return edges
+}
+
+func (r *webhookConnectionResolver) PageInfo() *pageInfoResolver {
+	return &pageInfoResolver{
+		hasNextPage: hasNextPage(r.offset, len(r.hooks), r.totalCount),
+		endCursor:   cursorForIndex(r.offset + len(r.hooks) - 1),
+	}
+}
+
+// pageInfoResolver adapts pagination bookkeeping to the schema's PageInfo type.
+type pageInfoResolver struct {
+	hasNextPage bool
+	endCursor   string
+}
+
+func (r *pageInfoResolver) HasNextPage() bool { return r.hasNextPage }
+
+func (r *pageInfoResolver) EndCursor() *string {
+	if !r.hasNextPage {
+		return nil
+	}
+	return &r.endCursor
+}
+
+// hookDeliveryResolver adapts a types.HookTask to the schema's HookDelivery type.
+type hookDeliveryResolver struct {
+	task *types.HookTask
+}
+
+func (r *hookDeliveryResolver) ID() graphql.ID { return graphql.ID(idToString(r.task.ID)) }
+func (r *hookDeliveryResolver) WebhookId() graphql.ID {
+	return graphql.ID(idToString(r.task.WebhookID))
+}
+func (r *hookDeliveryResolver) Trigger() string           { return r.task.Trigger }
+func (r *hookDeliveryResolver) ResponseStatusCode() int32 { return int32(r.task.ResponseStatusCode) }
+func (r *hookDeliveryResolver) IsDelivered() bool         { return r.task.IsDelivered }
+func (r *hookDeliveryResolver) Attempts() int32           { return int32(r.task.Attempts) }
+func (r *hookDeliveryResolver) Created() float64          { return float64(r.task.Created) }
+
+// hookDeliveryEdgeResolver adapts a single row of a hook task page to the
+// schema's Relay-style HookDeliveryEdge type.
+type hookDeliveryEdgeResolver struct {
+	task   *types.HookTask
+	cursor string
+}
+
+func (r *hookDeliveryEdgeResolver) Cursor() string { return r.cursor }
+
+func (r *hookDeliveryEdgeResolver) Node() *hookDeliveryResolver {
+	return &hookDeliveryResolver{task: r.task}
+}
+
+// hookDeliveryConnectionResolver adapts a page of hook tasks, plus the total
+// count needed to compute pageInfo, to the schema's HookDeliveryConnection type.
+type hookDeliveryConnectionResolver struct {
+	tasks      []*types.HookTask
+	totalCount int64
+	offset     int
+}
+
+func (r *hookDeliveryConnectionResolver) TotalCount() int32 { return int32(r.totalCount) }
+
+func (r *hookDeliveryConnectionResolver) Edges() []*hookDeliveryEdgeResolver {
+	edges := make([]*hookDeliveryEdgeResolver, len(r.tasks))
+	for i, task := range r.tasks {
+		edges[i] = &hookDeliveryEdgeResolver{task: task, cursor: cursorForIndex(r.offset + i)}
+	}
+	return edges
+}
+
+func (r *hookDeliveryConnectionResolver) PageInfo() *pageInfoResolver {
+	return &pageInfoResolver{
+		hasNextPage: hasNextPage(r.offset, len(r.tasks), r.totalCount),
+		endCursor:   cursorForIndex(r.offset + len(r.tasks) - 1),
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package database
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// secretEncrypter encrypts/decrypts small secret values (e.g. webhook headers)
+// for storage at rest using AES-GCM keyed off the server's encryption key.
+//
+// The output is base64 encoded and prefixed with the nonce, so it can be stored
+// in a plain text column alongside the other webhook fields.
+type secretEncrypter struct {
+	gcm cipher.AEAD
+}
+
+// newSecretEncrypter derives an AES-256-GCM encrypter from the given server key.
+// The key can be of any length - it's hashed down to 32 bytes with SHA-256.
+func newSecretEncrypter(key string) (*secretEncrypter, error) {
+	sum := sha256.Sum256([]byte(key))
+
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create aes cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gcm: %w", err)
+	}
+
+	return &secretEncrypter{gcm: gcm}, nil
+}
+
+// Encrypt returns the base64 encoded, encrypted form of plaintext.
+// An empty plaintext encrypts to an empty string so optional fields stay empty.
+func (e *secretEncrypter) Encrypt(plaintext string) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	nonce := make([]byte, e.gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := e.gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt reverses Encrypt.
+func (e *secretEncrypter) Decrypt(ciphertext string) (string, error) {
+	if ciphertext == "" {
+		return "", nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode ciphertext: %w", err)
+	}
+
+	nonceSize := e.gcm.NonceSize()
+	if len(raw) < nonceSize {
+		return "", fmt.Errorf("ciphertext too short")
+	}
+
+	nonce, sealed := raw[:nonceSize], raw[nonceSize:]
+
+	plaintext, err := e.gcm.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	return string(plaintext), nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package database
+
+import "testing"
+
+func TestSecretEncrypter_RoundTrip(t *testing.T) {
+	encrypter, err := newSecretEncrypter("server-key")
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+
+	const plaintext = "Bearer super-secret-token"
+
+	ciphertext, err := encrypter.Encrypt(plaintext)
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+	if ciphertext == plaintext {
+		t.Fatalf("ciphertext should not equal plaintext")
+	}
+
+	got, err := encrypter.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatalf("failed to decrypt: %v", err)
+	}
+	if got != plaintext {
+		t.Fatalf("Decrypt(Encrypt(%q)) = %q", plaintext, got)
+	}
+}
+
+func TestSecretEncrypter_EmptyPlaintext(t *testing.T) {
+	encrypter, err := newSecretEncrypter("server-key")
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+
+	ciphertext, err := encrypter.Encrypt("")
+	if err != nil {
+		t.Fatalf("failed to encrypt empty plaintext: %v", err)
+	}
+	if ciphertext != "" {
+		t.Fatalf("expected empty ciphertext for empty plaintext, got %q", ciphertext)
+	}
+
+	got, err := encrypter.Decrypt(ciphertext)
+	if err != nil {
+		t.Fatalf("failed to decrypt empty ciphertext: %v", err)
+	}
+	if got != "" {
+		t.Fatalf("expected empty plaintext, got %q", got)
+	}
+}
+
+func TestSecretEncrypter_DecryptWrongKeyFails(t *testing.T) {
+	encrypter, err := newSecretEncrypter("server-key")
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+
+	ciphertext, err := encrypter.Encrypt("Bearer super-secret-token")
+	if err != nil {
+		t.Fatalf("failed to encrypt: %v", err)
+	}
+
+	other, err := newSecretEncrypter("different-server-key")
+	if err != nil {
+		t.Fatalf("failed to create encrypter: %v", err)
+	}
+
+	if _, err := other.Decrypt(ciphertext); err == nil {
+		t.Fatalf("expected decrypt with the wrong key to fail")
+	}
+}
@@ -0,0 +1,330 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/internal/store"
+	"github.com/harness/gitness/internal/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.HookTaskStore = (*HookTaskStore)(nil)
+
+// NewHookTaskStore returns a new HookTaskStore.
+func NewHookTaskStore(db *sqlx.DB) *HookTaskStore {
+	return &HookTaskStore{
+		db: db,
+	}
+}
+
+// HookTaskStore implements store.HookTaskStore backed by a relational database.
+type HookTaskStore struct {
+	db *sqlx.DB
+}
+
+// hookTask is used to fetch hook task data from the database.
+// The object should be later re-packed into a different struct to return it as an API response.
+type hookTask struct {
+	ID        int64 `db:"hook_task_id"`
+	Version   int64 `db:"hook_task_version"`
+	WebhookID int64 `db:"hook_task_webhook_id"`
+	Created   int64 `db:"hook_task_created"`
+	Updated   int64 `db:"hook_task_updated"`
+
+	Trigger string `db:"hook_task_trigger"`
+
+	RequestURL     string `db:"hook_task_request_url"`
+	RequestHeaders string `db:"hook_task_request_headers"`
+	RequestBody    string `db:"hook_task_request_body"`
+
+	ResponseStatusCode int    `db:"hook_task_response_status_code"`
+	ResponseHeaders    string `db:"hook_task_response_headers"`
+	ResponseBody       string `db:"hook_task_response_body"`
+
+	Attempts        int   `db:"hook_task_attempts"`
+	IsDelivered     bool  `db:"hook_task_is_delivered"`
+	NextDeliveredAt int64 `db:"hook_task_next_delivered_at"`
+	IsAbandoned     bool  `db:"hook_task_is_abandoned"`
+}
+
+const (
+	hookTaskColumns = `
+		 hook_task_id
+		,hook_task_version
+		,hook_task_webhook_id
+		,hook_task_created
+		,hook_task_updated
+		,hook_task_trigger
+		,hook_task_request_url
+		,hook_task_request_headers
+		,hook_task_request_body
+		,hook_task_response_status_code
+		,hook_task_response_headers
+		,hook_task_response_body
+		,hook_task_attempts
+		,hook_task_is_delivered
+		,hook_task_next_delivered_at
+		,hook_task_is_abandoned`
+
+	hookTaskSelectBase = `
+	SELECT` + hookTaskColumns + `
+	FROM hook_tasks`
+)
+
+// Find finds the hook task by id.
+func (s *HookTaskStore) Find(ctx context.Context, id int64) (*types.HookTask, error) {
+	const sqlQuery = hookTaskSelectBase + `
+		WHERE hook_task_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &hookTask{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, processSQLErrorf(err, "Select query failed")
+	}
+
+	return mapToHookTask(dst), nil
+}
+
+// Create creates a new hook task.
+func (s *HookTaskStore) Create(ctx context.Context, task *types.HookTask) error {
+	const sqlQuery = `
+		INSERT INTO hook_tasks (
+			 hook_task_webhook_id
+			,hook_task_created
+			,hook_task_updated
+			,hook_task_trigger
+			,hook_task_request_url
+			,hook_task_request_headers
+			,hook_task_request_body
+			,hook_task_response_status_code
+			,hook_task_response_headers
+			,hook_task_response_body
+			,hook_task_attempts
+			,hook_task_is_delivered
+			,hook_task_next_delivered_at
+			,hook_task_is_abandoned
+		) values (
+			 :hook_task_webhook_id
+			,:hook_task_created
+			,:hook_task_updated
+			,:hook_task_trigger
+			,:hook_task_request_url
+			,:hook_task_request_headers
+			,:hook_task_request_body
+			,:hook_task_response_status_code
+			,:hook_task_response_headers
+			,:hook_task_response_body
+			,:hook_task_attempts
+			,:hook_task_is_delivered
+			,:hook_task_next_delivered_at
+			,:hook_task_is_abandoned
+		) RETURNING hook_task_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbTask := mapToInternalHookTask(task)
+
+	query, arg, err := db.BindNamed(sqlQuery, dbTask)
+	if err != nil {
+		return processSQLErrorf(err, "Failed to bind hook task object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, arg...).Scan(&task.ID); err != nil {
+		return processSQLErrorf(err, "Insert query failed")
+	}
+
+	return nil
+}
+
+// Update updates an existing hook task.
+func (s *HookTaskStore) Update(ctx context.Context, task *types.HookTask) error {
+	const sqlQuery = `
+		UPDATE hook_tasks
+		SET
+			 hook_task_version = :hook_task_version
+			,hook_task_updated = :hook_task_updated
+			,hook_task_request_headers = :hook_task_request_headers
+			,hook_task_request_body = :hook_task_request_body
+			,hook_task_response_status_code = :hook_task_response_status_code
+			,hook_task_response_headers = :hook_task_response_headers
+			,hook_task_response_body = :hook_task_response_body
+			,hook_task_attempts = :hook_task_attempts
+			,hook_task_is_delivered = :hook_task_is_delivered
+			,hook_task_next_delivered_at = :hook_task_next_delivered_at
+			,hook_task_is_abandoned = :hook_task_is_abandoned
+		WHERE hook_task_id = :hook_task_id and hook_task_version = :hook_task_version - 1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbTask := mapToInternalHookTask(task)
+
+	// update Version (used for optimistic locking) and Updated time
+	dbTask.Version++
+	dbTask.Updated = task.Updated
+
+	query, arg, err := db.BindNamed(sqlQuery, dbTask)
+	if err != nil {
+		return processSQLErrorf(err, "Failed to bind hook task object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return processSQLErrorf(err, "failed to update hook task")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return processSQLErrorf(err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return store.ErrConflict
+	}
+
+	task.Version = dbTask.Version
+	task.Updated = dbTask.Updated
+
+	return nil
+}
+
+// List lists the hook tasks for a given webhook.
+func (s *HookTaskStore) List(ctx context.Context, webhookID int64,
+	opts *types.HookTaskFilter) ([]*types.HookTask, error) {
+	stmt := builder.
+		Select(hookTaskColumns).
+		From("hook_tasks").
+		Where("hook_task_webhook_id = ?", webhookID).
+		Limit(uint64(limit(opts.Size))).
+		Offset(uint64(offset(opts.Page, opts.Size))).
+		OrderBy("hook_task_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*hookTask{}
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, processSQLErrorf(err, "Select query failed")
+	}
+
+	return mapToHookTasks(dst), nil
+}
+
+// Count counts the hook tasks for a given webhook.
+func (s *HookTaskStore) Count(ctx context.Context, webhookID int64) (int64, error) {
+	const sqlQuery = `
+		SELECT count(*)
+		FROM hook_tasks
+		WHERE hook_task_webhook_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err := db.QueryRowContext(ctx, sqlQuery, webhookID).Scan(&count); err != nil {
+		return 0, processSQLErrorf(err, "Failed executing count query")
+	}
+
+	return count, nil
+}
+
+// ListUndelivered lists the hook tasks that are due for (re)delivery.
+// Abandoned tasks (those that already exhausted MaxRetries) are excluded, even
+// though they're still formally "undelivered", so they aren't redelivered forever.
+func (s *HookTaskStore) ListUndelivered(ctx context.Context, now int64, limit int) ([]*types.HookTask, error) {
+	const sqlQuery = hookTaskSelectBase + `
+		WHERE hook_task_is_delivered = false and hook_task_is_abandoned = false
+			and hook_task_next_delivered_at <= $1
+		ORDER BY hook_task_next_delivered_at ASC
+		LIMIT $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*hookTask{}
+	if err := db.SelectContext(ctx, &dst, sqlQuery, now, limit); err != nil {
+		return nil, processSQLErrorf(err, "Select query failed")
+	}
+
+	return mapToHookTasks(dst), nil
+}
+
+// Purge deletes delivered hook tasks that were last updated before the given time.
+func (s *HookTaskStore) Purge(ctx context.Context, olderThan int64) (int64, error) {
+	const sqlQuery = `
+		DELETE FROM hook_tasks
+		WHERE hook_task_is_delivered = true and hook_task_updated < $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, olderThan)
+	if err != nil {
+		return 0, processSQLErrorf(err, "The delete query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, processSQLErrorf(err, "Failed to get number of deleted rows")
+	}
+
+	return count, nil
+}
+
+func mapToHookTask(task *hookTask) *types.HookTask {
+	return &types.HookTask{
+		ID:                 task.ID,
+		Version:            task.Version,
+		WebhookID:          task.WebhookID,
+		Created:            task.Created,
+		Updated:            task.Updated,
+		Trigger:            task.Trigger,
+		RequestURL:         task.RequestURL,
+		RequestHeaders:     task.RequestHeaders,
+		RequestBody:        task.RequestBody,
+		ResponseStatusCode: task.ResponseStatusCode,
+		ResponseHeaders:    task.ResponseHeaders,
+		ResponseBody:       task.ResponseBody,
+		Attempts:           task.Attempts,
+		IsDelivered:        task.IsDelivered,
+		NextDeliveredAt:    task.NextDeliveredAt,
+		IsAbandoned:        task.IsAbandoned,
+	}
+}
+
+func mapToInternalHookTask(task *types.HookTask) *hookTask {
+	return &hookTask{
+		ID:                 task.ID,
+		Version:            task.Version,
+		WebhookID:          task.WebhookID,
+		Created:            task.Created,
+		Updated:            task.Updated,
+		Trigger:            task.Trigger,
+		RequestURL:         task.RequestURL,
+		RequestHeaders:     task.RequestHeaders,
+		RequestBody:        task.RequestBody,
+		ResponseStatusCode: task.ResponseStatusCode,
+		ResponseHeaders:    task.ResponseHeaders,
+		ResponseBody:       task.ResponseBody,
+		Attempts:           task.Attempts,
+		IsDelivered:        task.IsDelivered,
+		NextDeliveredAt:    task.NextDeliveredAt,
+		IsAbandoned:        task.IsAbandoned,
+	}
+}
+
+func mapToHookTasks(tasks []*hookTask) []*types.HookTask {
+	m := make([]*types.HookTask, len(tasks))
+	for i, task := range tasks {
+		m[i] = mapToHookTask(task)
+	}
+	return m
+}
@@ -12,9 +12,11 @@ import (
 
 	"github.com/harness/gitness/internal/store"
 	"github.com/harness/gitness/internal/store/database/dbtx"
+	"github.com/harness/gitness/internal/webhook/httpclient"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/Masterminds/squirrel"
 	"github.com/guregu/null"
 	"github.com/jmoiron/sqlx"
 )
@@ -22,15 +24,28 @@ import (
 var _ store.WebhookStore = (*WebhookStore)(nil)
 
 // NewWebhookStore returns a new WebhookStore.
-func NewWebhookStore(db *sqlx.DB) *WebhookStore {
-	return &WebhookStore{
-		db: db,
+// encryptionKey is used to derive the at-rest encryption of sensitive webhook
+// fields (e.g. the custom Authorization header) and must stay stable across
+// restarts, or previously stored secrets become undecryptable.
+// urlPolicy is the SSRF policy new/updated webhook URLs are validated against.
+func NewWebhookStore(db *sqlx.DB, encryptionKey string, urlPolicy httpclient.Policy) (*WebhookStore, error) {
+	encrypter, err := newSecretEncrypter(encryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secret encrypter: %w", err)
 	}
+
+	return &WebhookStore{
+		db:        db,
+		encrypter: encrypter,
+		urlPolicy: urlPolicy,
+	}, nil
 }
 
 // WebhookStore implements store.Webhook backed by a relational database.
 type WebhookStore struct {
-	db *sqlx.DB
+	db        *sqlx.DB
+	encrypter *secretEncrypter
+	urlPolicy httpclient.Policy
 }
 
 // webhook is used to fetch webhook data from the database.
@@ -49,6 +64,13 @@ type webhook struct {
 	Enabled  bool   `db:"webhook_enabled"`
 	Insecure bool   `db:"webhook_insecure"`
 	Triggers string `db:"webhook_triggers"`
+	Format   string `db:"webhook_format"`
+
+	// AuthorizationHeader holds the encrypted Authorization header value, if any.
+	AuthorizationHeader null.String `db:"webhook_authorization_header"`
+
+	// AllowPrivate opts this webhook out of the default SSRF protections.
+	AllowPrivate bool `db:"webhook_allow_private"`
 }
 
 const (
@@ -64,7 +86,10 @@ const (
 		,webhook_secret
 		,webhook_enabled
 		,webhook_insecure
-		,webhook_triggers`
+		,webhook_triggers
+		,webhook_format
+		,webhook_authorization_header
+		,webhook_allow_private`
 
 	webhookSelectBase = `
 	SELECT` + webhookColumns + `
@@ -83,7 +108,7 @@ func (s *WebhookStore) Find(ctx context.Context, id int64) (*types.Webhook, erro
 		return nil, processSQLErrorf(err, "Select query failed")
 	}
 
-	res, err := mapToWebhook(dst)
+	res, err := s.mapToWebhook(dst)
 	if err != nil {
 		return nil, fmt.Errorf("failed to map webhook to external type: %w", err)
 	}
@@ -105,6 +130,9 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_enabled
 			,webhook_insecure
 			,webhook_triggers
+			,webhook_format
+			,webhook_authorization_header
+			,webhook_allow_private
 		) values (
 			:webhook_repo_id
 			,:webhook_space_id
@@ -116,11 +144,18 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_enabled
 			,:webhook_insecure
 			,:webhook_triggers
+			,:webhook_format
+			,:webhook_authorization_header
+			,:webhook_allow_private
 		) RETURNING webhook_id`
 
+	if err := s.validateURL(hook); err != nil {
+		return err
+	}
+
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dbHook, err := mapToInternalWebhook(hook)
+	dbHook, err := s.mapToInternalWebhook(hook)
 	if err != nil {
 		return fmt.Errorf("failed to map webhook to internal db type: %w", err)
 	}
@@ -149,11 +184,18 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			,webhook_enabled = :webhook_enabled
 			,webhook_insecure = :webhook_insecure
 			,webhook_triggers = :webhook_triggers
+			,webhook_format = :webhook_format
+			,webhook_authorization_header = :webhook_authorization_header
+			,webhook_allow_private = :webhook_allow_private
 		WHERE webhook_id = :webhook_id and webhook_version = :webhook_version - 1`
 
+	if err := s.validateURL(hook); err != nil {
+		return err
+	}
+
 	db := dbtx.GetAccessor(ctx, s.db)
 
-	dbHook, err := mapToInternalWebhook(hook)
+	dbHook, err := s.mapToInternalWebhook(hook)
 	if err != nil {
 		return fmt.Errorf("failed to map webhook to internal db type: %w", err)
 	}
@@ -187,6 +229,21 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 	return nil
 }
 
+// validateURL rejects webhook URLs that target a disallowed address upfront,
+// so obviously-bad targets are caught before a hook task is ever created for
+// them. hook.AllowPrivate relaxes the policy for this single webhook; it's the
+// caller's responsibility to only let site-admins set it.
+func (s *WebhookStore) validateURL(hook *types.Webhook) error {
+	policy := s.urlPolicy
+	policy.AllowPrivate = hook.AllowPrivate
+
+	if err := httpclient.ValidateURL(hook.URL, policy); err != nil {
+		return fmt.Errorf("webhook url rejected: %w", err)
+	}
+
+	return nil
+}
+
 // Delete deletes the webhook for the given id.
 func (s *WebhookStore) Delete(ctx context.Context, id int64) error {
 	const sqlQuery = `
@@ -248,6 +305,14 @@ func (s *WebhookStore) List(ctx context.Context, parentType enum.WebhookParent,
 		return nil, fmt.Errorf("webhook parent type '%s' is not supported", parentType)
 	}
 
+	if len(opts.Formats) > 0 {
+		formats := make([]string, len(opts.Formats))
+		for i, f := range opts.Formats {
+			formats[i] = string(f)
+		}
+		stmt = stmt.Where(squirrel.Eq{"webhook_format": formats})
+	}
+
 	stmt = stmt.Limit(uint64(limit(opts.Size)))
 	stmt = stmt.Offset(uint64(offset(opts.Page, opts.Size)))
 
@@ -266,7 +331,7 @@ func (s *WebhookStore) List(ctx context.Context, parentType enum.WebhookParent,
 		return nil, processSQLErrorf(err, "Select query failed")
 	}
 
-	res, err := mapToWebhooks(dst)
+	res, err := s.mapToWebhooks(dst)
 	if err != nil {
 		return nil, fmt.Errorf("failed to map webhooks to external type: %w", err)
 	}
@@ -274,18 +339,27 @@ func (s *WebhookStore) List(ctx context.Context, parentType enum.WebhookParent,
 	return res, nil
 }
 
-func mapToWebhook(hook *webhook) (*types.Webhook, error) {
+func (s *WebhookStore) mapToWebhook(hook *webhook) (*types.Webhook, error) {
+	authHeader, err := s.encrypter.Decrypt(hook.AuthorizationHeader.ValueOrZero())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt authorization header for hook %d: %w", hook.ID, err)
+	}
+
 	res := &types.Webhook{
-		ID:        hook.ID,
-		Version:   hook.Version,
-		CreatedBy: hook.CreatedBy,
-		Created:   hook.Created,
-		Updated:   hook.Updated,
-		URL:       hook.URL,
-		Secret:    hook.Secret,
-		Enabled:   hook.Enabled,
-		Insecure:  hook.Insecure,
-		Triggers:  triggersFromString(hook.Triggers),
+		ID:                     hook.ID,
+		Version:                hook.Version,
+		CreatedBy:              hook.CreatedBy,
+		Created:                hook.Created,
+		Updated:                hook.Updated,
+		URL:                    hook.URL,
+		Secret:                 hook.Secret,
+		Enabled:                hook.Enabled,
+		Insecure:               hook.Insecure,
+		Triggers:               triggersFromString(hook.Triggers),
+		Format:                 enum.WebhookFormat(hook.Format),
+		AuthorizationHeader:    authHeader,
+		HasAuthorizationHeader: authHeader != "",
+		AllowPrivate:           hook.AllowPrivate,
 	}
 
 	switch {
@@ -304,18 +378,28 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 	return res, nil
 }
 
-func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
+func (s *WebhookStore) mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
+	authHeader, err := s.encrypter.Encrypt(hook.AuthorizationHeader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt authorization header: %w", err)
+	}
+
 	res := &webhook{
-		ID:        hook.ID,
-		Version:   hook.Version,
-		CreatedBy: hook.CreatedBy,
-		Created:   hook.Created,
-		Updated:   hook.Updated,
-		URL:       hook.URL,
-		Secret:    hook.Secret,
-		Enabled:   hook.Enabled,
-		Insecure:  hook.Insecure,
-		Triggers:  triggersToString(hook.Triggers),
+		ID:           hook.ID,
+		Version:      hook.Version,
+		CreatedBy:    hook.CreatedBy,
+		Created:      hook.Created,
+		Updated:      hook.Updated,
+		URL:          hook.URL,
+		Secret:       hook.Secret,
+		Enabled:      hook.Enabled,
+		Insecure:     hook.Insecure,
+		Triggers:     triggersToString(hook.Triggers),
+		Format:       string(hook.Format),
+		AllowPrivate: hook.AllowPrivate,
+	}
+	if authHeader != "" {
+		res.AuthorizationHeader = null.StringFrom(authHeader)
 	}
 
 	switch hook.ParentType {
@@ -330,11 +414,11 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 	return res, nil
 }
 
-func mapToWebhooks(hooks []*webhook) ([]*types.Webhook, error) {
+func (s *WebhookStore) mapToWebhooks(hooks []*webhook) ([]*types.Webhook, error) {
 	var err error
 	m := make([]*types.Webhook, len(hooks))
 	for i, hook := range hooks {
-		m[i], err = mapToWebhook(hook)
+		m[i], err = s.mapToWebhook(hook)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,36 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package store
+
+import (
+	"context"
+
+	"github.com/harness/gitness/types"
+)
+
+// HookTaskStore defines the storage layer for delivery attempts of webhooks.
+type HookTaskStore interface {
+	// Find finds the hook task by id.
+	Find(ctx context.Context, id int64) (*types.HookTask, error)
+
+	// Create creates a new hook task.
+	Create(ctx context.Context, task *types.HookTask) error
+
+	// Update updates an existing hook task.
+	Update(ctx context.Context, task *types.HookTask) error
+
+	// List lists the hook tasks for a given webhook.
+	List(ctx context.Context, webhookID int64, opts *types.HookTaskFilter) ([]*types.HookTask, error)
+
+	// Count counts the hook tasks for a given webhook.
+	Count(ctx context.Context, webhookID int64) (int64, error)
+
+	// ListUndelivered lists the hook tasks that are due for (re)delivery, i.e.
+	// tasks that haven't been delivered or abandoned and whose next_delivered_at has passed.
+	ListUndelivered(ctx context.Context, now int64, limit int) ([]*types.HookTask, error)
+
+	// Purge deletes delivered hook tasks that were last updated before the given time.
+	Purge(ctx context.Context, olderThan int64) (int64, error)
+}
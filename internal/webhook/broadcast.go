@@ -0,0 +1,54 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package webhook
+
+import (
+	"sync"
+
+	"github.com/harness/gitness/types"
+)
+
+// Subscribe registers a listener that receives the HookTasks the delivery
+// worker attempts (successful or not) from this point on, buffered up to 16;
+// while the buffer is full, further tasks are dropped for it (see notify). The returned
+// unsubscribe func must be called once the caller is done listening, or the
+// listener's channel is leaked.
+func (s *Service) Subscribe() (<-chan *types.HookTask, func()) {
+	s.listenerMu.Lock()
+	defer s.listenerMu.Unlock()
+
+	if s.listeners == nil {
+		s.listeners = make(map[int]chan *types.HookTask)
+	}
+
+	id := s.nextListenerID
+	s.nextListenerID++
+
+	ch := make(chan *types.HookTask, 16)
+	s.listeners[id] = ch
+
+	unsubscribe := func() {
+		s.listenerMu.Lock()
+		defer s.listenerMu.Unlock()
+		delete(s.listeners, id)
+		close(ch)
+	}
+
+	return ch, unsubscribe
+}
+
+// notify broadcasts task to every current subscriber, dropping it for any
+// listener whose channel is full rather than blocking the delivery worker.
+func (s *Service) notify(task *types.HookTask) {
+	s.listenerMu.Lock()
+	defer s.listenerMu.Unlock()
+
+	for _, ch := range s.listeners {
+		select {
+		case ch <- task:
+		default:
+		}
+	}
+}
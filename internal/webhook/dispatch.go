@@ -0,0 +1,67 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/internal/webhook/format"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// DispatchRepositoryEvent enqueues event for every enabled webhook on parentID
+// (parentType) whose triggers include event.Trigger. It's the single place that
+// decides whether a configured webhook cares about a given repository event, so
+// a new enum.WebhookTrigger value only needs to be listed in
+// WebhookTrigger.HasRepositoryEvent and emitted at the call site that constructs
+// the format.Event.
+//
+// A single webhook failing to enqueue (e.g. an unsupported format) is logged and
+// skipped rather than aborting the loop, so one broken webhook on a repo/space
+// can't prevent every other webhook there from receiving the event.
+func (s *Service) DispatchRepositoryEvent(
+	ctx context.Context,
+	parentType enum.WebhookParent,
+	parentID int64,
+	event *format.Event,
+) error {
+	if !event.Trigger.HasRepositoryEvent() {
+		return fmt.Errorf("trigger '%s' is not a repository event", event.Trigger)
+	}
+
+	hooks, err := s.webhookStore.List(ctx, parentType, parentID, &types.WebhookFilter{Size: maxWebhooksPerParent})
+	if err != nil {
+		return fmt.Errorf("failed to list webhooks for %s %d: %w", parentType, parentID, err)
+	}
+
+	for _, hook := range hooks {
+		if !hook.Enabled || !hasTrigger(hook.Triggers, event.Trigger) {
+			continue
+		}
+
+		if err := s.Enqueue(ctx, hook, event); err != nil {
+			log.Ctx(ctx).Err(err).Msgf("webhook: failed to enqueue hook task for webhook %d", hook.ID)
+		}
+	}
+
+	return nil
+}
+
+// maxWebhooksPerParent bounds how many webhooks are loaded per dispatch - in
+// practice a repo or space has a handful of webhooks configured at most.
+const maxWebhooksPerParent = 100
+
+func hasTrigger(triggers []enum.WebhookTrigger, trigger enum.WebhookTrigger) bool {
+	for _, t := range triggers {
+		if t == trigger {
+			return true
+		}
+	}
+	return false
+}
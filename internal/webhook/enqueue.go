@@ -0,0 +1,45 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/internal/webhook/format"
+	"github.com/harness/gitness/types"
+)
+
+// Enqueue formats event using hook's configured format and persists it as a new,
+// pending HookTask for the delivery worker to pick up.
+func (s *Service) Enqueue(ctx context.Context, hook *types.Webhook, event *format.Event) error {
+	formatter, err := format.Get(hook.Format)
+	if err != nil {
+		return fmt.Errorf("failed to resolve webhook formatter: %w", err)
+	}
+
+	body, err := formatter.Format(event)
+	if err != nil {
+		return fmt.Errorf("failed to format webhook payload: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	task := &types.HookTask{
+		WebhookID:       hook.ID,
+		Created:         now,
+		Updated:         now,
+		Trigger:         string(event.Trigger),
+		RequestURL:      hook.URL,
+		RequestBody:     string(body),
+		NextDeliveredAt: now,
+	}
+
+	if err = s.hookTaskStore.Create(ctx, task); err != nil {
+		return fmt.Errorf("failed to create hook task: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// dingtalkFormatter emits a DingTalk "markdown" robot message:
+// https://open.dingtalk.com/document/robots/custom-robot-access
+type dingtalkFormatter struct{}
+
+type dingtalkPayload struct {
+	MsgType  string          `json:"msgtype"`
+	Markdown dingtalkContent `json:"markdown"`
+}
+
+type dingtalkContent struct {
+	Title string `json:"title"`
+	Text  string `json:"text"`
+}
+
+func (dingtalkFormatter) Format(event *Event) ([]byte, error) {
+	text := fmt.Sprintf("#### %s\n%s", event.Title, event.Description)
+	if event.URL != "" {
+		text += fmt.Sprintf("\n\n[View in Gitness](%s)", event.URL)
+	}
+
+	payload := dingtalkPayload{
+		MsgType: "markdown",
+		Markdown: dingtalkContent{
+			Title: event.Title,
+			Text:  text,
+		},
+	}
+
+	return json.Marshal(payload)
+}
@@ -0,0 +1,43 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package format
+
+import "encoding/json"
+
+// discordFormatter emits Discord's "execute webhook" payload shape:
+// https://discord.com/developers/docs/resources/webhook#execute-webhook
+type discordFormatter struct{}
+
+type discordPayload struct {
+	Username string         `json:"username"`
+	Embeds   []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string `json:"title"`
+	URL         string `json:"url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Footer      *struct {
+		Text string `json:"text"`
+	} `json:"footer,omitempty"`
+}
+
+func (discordFormatter) Format(event *Event) ([]byte, error) {
+	payload := discordPayload{
+		Username: "Gitness",
+		Embeds: []discordEmbed{
+			{
+				Title:       event.Title,
+				URL:         event.URL,
+				Description: event.Description,
+				Footer: &struct {
+					Text string `json:"text"`
+				}{Text: event.RepoName},
+			},
+		},
+	}
+
+	return json.Marshal(payload)
+}
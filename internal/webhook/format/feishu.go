@@ -0,0 +1,37 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// feishuFormatter emits a Feishu/Lark "text" robot message:
+// https://open.feishu.cn/document/client-docs/bot-v3/add-custom-bot
+type feishuFormatter struct{}
+
+type feishuPayload struct {
+	MsgType string            `json:"msg_type"`
+	Content feishuTextContent `json:"content"`
+}
+
+type feishuTextContent struct {
+	Text string `json:"text"`
+}
+
+func (feishuFormatter) Format(event *Event) ([]byte, error) {
+	text := fmt.Sprintf("%s\n%s", event.Title, event.Description)
+	if event.URL != "" {
+		text += fmt.Sprintf("\n%s", event.URL)
+	}
+
+	payload := feishuPayload{
+		MsgType: "text",
+		Content: feishuTextContent{Text: text},
+	}
+
+	return json.Marshal(payload)
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+// Package format converts canonical webhook events into the payload shape expected
+// by third-party chat/collaboration tools (Slack, Discord, MS Teams, Matrix, ...),
+// so a webhook can point directly at them without a translation proxy in between.
+package format
+
+import (
+	"fmt"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+// Event is the canonical representation of whatever triggered the webhook,
+// independent of the target payload shape.
+type Event struct {
+	Trigger enum.WebhookTrigger
+
+	RepoName string
+	RepoURL  string
+
+	// Title is a short, human readable summary of the event
+	// (e.g. "Branch 'main' created", "PR #12: fix flaky test").
+	Title string
+
+	// Description adds additional context (e.g. the PR description, the pushed
+	// commit messages). May be empty.
+	Description string
+
+	// ActorName is the display name of the user that triggered the event.
+	ActorName string
+
+	// URL links back to the relevant object in Gitness (repo, PR, ...).
+	URL string
+}
+
+// Formatter transforms a canonical Event into the raw JSON body to POST to the
+// target service. It returns the body only; it does not add any request headers.
+type Formatter interface {
+	Format(event *Event) ([]byte, error)
+}
+
+// formatters holds the registered Formatter for every supported enum.WebhookFormat.
+var formatters = map[enum.WebhookFormat]Formatter{
+	enum.WebhookFormatGeneric:  genericFormatter{},
+	enum.WebhookFormatSlack:    slackFormatter{},
+	enum.WebhookFormatDiscord:  discordFormatter{},
+	enum.WebhookFormatMSTeams:  msteamsFormatter{},
+	enum.WebhookFormatMatrix:   matrixFormatter{},
+	enum.WebhookFormatDingtalk: dingtalkFormatter{},
+	enum.WebhookFormatFeishu:   feishuFormatter{},
+}
+
+// Get returns the Formatter registered for the given format.
+func Get(f enum.WebhookFormat) (Formatter, error) {
+	formatter, ok := formatters[f]
+	if !ok {
+		return nil, fmt.Errorf("webhook format '%s' is not supported", f)
+	}
+
+	return formatter, nil
+}
@@ -0,0 +1,15 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package format
+
+import "encoding/json"
+
+// genericFormatter emits the canonical Gitness event as-is, for consumers that
+// build their own integration on top of it.
+type genericFormatter struct{}
+
+func (genericFormatter) Format(event *Event) ([]byte, error) {
+	return json.Marshal(event)
+}
@@ -0,0 +1,45 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+)
+
+// matrixFormatter emits a Matrix `m.room.message` event body, suitable for posting
+// to a room via the client-server `PUT /rooms/{roomId}/send/m.room.message/{txnId}`
+// API (the webhook_url is expected to already include the room/txn path).
+type matrixFormatter struct{}
+
+type matrixPayload struct {
+	MsgType       string `json:"msgtype"`
+	Body          string `json:"body"`
+	Format        string `json:"format"`
+	FormattedBody string `json:"formatted_body"`
+}
+
+func (matrixFormatter) Format(event *Event) ([]byte, error) {
+	plain := event.Title
+	if event.Description != "" {
+		plain = fmt.Sprintf("%s\n%s", event.Title, event.Description)
+	}
+
+	formattedBody := fmt.Sprintf("<strong>%s</strong>", html.EscapeString(event.Title))
+	if event.URL != "" {
+		formattedBody = fmt.Sprintf(`<a href="%s">%s</a>`,
+			html.EscapeString(event.URL), html.EscapeString(event.Title))
+	}
+
+	payload := matrixPayload{
+		MsgType:       "m.text",
+		Body:          plain,
+		Format:        "org.matrix.custom.html",
+		FormattedBody: formattedBody,
+	}
+
+	return json.Marshal(payload)
+}
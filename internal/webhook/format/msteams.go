@@ -0,0 +1,55 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package format
+
+import "encoding/json"
+
+// msteamsFormatter emits a MS Teams "Office 365 connector" MessageCard:
+// https://learn.microsoft.com/en-us/outlook/actionable-messages/message-card-reference
+type msteamsFormatter struct{}
+
+type msteamsPayload struct {
+	Type             string          `json:"@type"`
+	Context          string          `json:"@context"`
+	Summary          string          `json:"summary"`
+	Title            string          `json:"title"`
+	Text             string          `json:"text,omitempty"`
+	PotentialActions []msteamsAction `json:"potentialAction,omitempty"`
+}
+
+type msteamsAction struct {
+	Type    string          `json:"@type"`
+	Name    string          `json:"name"`
+	Targets []msteamsTarget `json:"targets"`
+}
+
+type msteamsTarget struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+func (msteamsFormatter) Format(event *Event) ([]byte, error) {
+	payload := msteamsPayload{
+		Type:    "MessageCard",
+		Context: "http://schema.org/extensions",
+		Summary: event.Title,
+		Title:   event.Title,
+		Text:    event.Description,
+	}
+
+	if event.URL != "" {
+		payload.PotentialActions = []msteamsAction{
+			{
+				Type: "OpenUri",
+				Name: "View in Gitness",
+				Targets: []msteamsTarget{
+					{OS: "default", URI: event.URL},
+				},
+			},
+		}
+	}
+
+	return json.Marshal(payload)
+}
@@ -0,0 +1,39 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package format
+
+import "encoding/json"
+
+// slackFormatter emits Slack's "incoming webhook" payload shape:
+// https://api.slack.com/messaging/webhooks
+type slackFormatter struct{}
+
+type slackPayload struct {
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments,omitempty"`
+}
+
+type slackAttachment struct {
+	Title     string `json:"title"`
+	TitleLink string `json:"title_link,omitempty"`
+	Text      string `json:"text,omitempty"`
+	Footer    string `json:"footer,omitempty"`
+}
+
+func (slackFormatter) Format(event *Event) ([]byte, error) {
+	payload := slackPayload{
+		Text: event.Title,
+		Attachments: []slackAttachment{
+			{
+				Title:     event.Title,
+				TitleLink: event.URL,
+				Text:      event.Description,
+				Footer:    event.RepoName,
+			},
+		},
+	}
+
+	return json.Marshal(payload)
+}
@@ -0,0 +1,53 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package webhook
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// redactedHeader is the value substituted for request headers that carry a
+// secret, so it never reaches HookTask.RequestHeaders - that column is
+// exposed verbatim by ListHookTasks/Redeliver to anyone with repo-view
+// access, and the Authorization header is the plaintext secretEncrypter is
+// meant to protect at rest.
+const redactedHeader = "***"
+
+// redactedRequestHeaders is the set of request header names whose values must
+// never be persisted or serialized, compared case-insensitively as
+// http.Header already canonicalizes keys.
+var redactedRequestHeaders = map[string]bool{
+	"Authorization": true,
+}
+
+// headersToString renders h as newline-separated "Key: value" lines (sorted for
+// determinism), the format HookTask.RequestHeaders/ResponseHeaders are stored in
+// so a delivery's actual wire headers can be inspected after the fact. Values of
+// redactedRequestHeaders are replaced with redactedHeader rather than persisted.
+func headersToString(h http.Header) string {
+	keys := make([]string, 0, len(h))
+	for k := range h {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		v := h[k]
+		if redactedRequestHeaders[k] {
+			v = []string{redactedHeader}
+		}
+		for _, value := range v {
+			b.WriteString(k)
+			b.WriteString(": ")
+			b.WriteString(value)
+			b.WriteString("\n")
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package httpclient
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// New returns an http.Client whose Transport refuses to connect to any address
+// blocked by policy. insecure disables TLS certificate verification, mirroring
+// the per-webhook webhook_insecure flag.
+func New(policy Policy, insecure bool, timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: controlFunc(policy),
+	}
+
+	//nolint:gosec // InsecureSkipVerify is explicitly opted into per-webhook.
+	transport := &http.Transport{
+		DialContext:     dialer.DialContext,
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: insecure},
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+// controlFunc returns a net.Dialer.Control hook that rejects the connection
+// before the TCP handshake if the resolved address is blocked by policy.
+func controlFunc(policy Policy) func(network, address string, c syscall.RawConn) error {
+	return func(_, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return fmt.Errorf("failed to split dial address %q: %w", address, err)
+		}
+
+		ip := net.ParseIP(host)
+		if ip == nil {
+			// should not happen - by the time Control is called the host has
+			// already been resolved to an IP address by the dialer.
+			return fmt.Errorf("dial address %q did not resolve to an IP", host)
+		}
+
+		if policy.IsBlocked(ip) {
+			return fmt.Errorf("connections to %s are not allowed", ip)
+		}
+
+		return nil
+	}
+}
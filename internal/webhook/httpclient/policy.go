@@ -0,0 +1,86 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+// Package httpclient provides an SSRF-safe http.Client for delivering webhooks:
+// it refuses to connect to loopback, link-local, and private addresses (plus any
+// admin-configured CIDR blocks) before the TCP connect completes, so a
+// user-supplied webhook URL can't be used to probe the internal network.
+package httpclient
+
+import (
+	"fmt"
+	"net"
+)
+
+// defaultDeniedCIDRs are rejected unless Policy.AllowPrivate is set - they cover
+// loopback and link-local ranges for IPv4 and IPv6, the RFC1918 private IPv4
+// ranges, and the IPv6 unique local range.
+var defaultDeniedCIDRs = mustParseCIDRs(
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"10.0.0.0/8",
+	"172.16.0.0/12",
+	"192.168.0.0/16",
+	"::1/128",
+	"fe80::/10",
+	"fc00::/7",
+)
+
+// Policy controls which destination addresses webhook deliveries may connect to.
+type Policy struct {
+	// AllowPrivate lets a webhook target the default denied ranges above. It's
+	// intended to be settable only by site-admins (via webhook_allow_private).
+	AllowPrivate bool
+
+	// DenyCIDRs are additional admin-supplied blocks that are rejected regardless
+	// of AllowPrivate - e.g. a cloud metadata endpoint or an internal subnet.
+	DenyCIDRs []*net.IPNet
+}
+
+// IsBlocked reports whether ip is disallowed by the policy.
+func (p Policy) IsBlocked(ip net.IP) bool {
+	for _, block := range p.DenyCIDRs {
+		if block.Contains(ip) {
+			return true
+		}
+	}
+
+	if p.AllowPrivate {
+		return false
+	}
+
+	for _, block := range defaultDeniedCIDRs {
+		if block.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	nets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(fmt.Sprintf("invalid built-in CIDR %q: %v", cidr, err))
+		}
+		nets[i] = ipNet
+	}
+	return nets
+}
+
+// ParseCIDRs parses admin-supplied CIDR strings into Policy.DenyCIDRs, returning a
+// clear error identifying the first invalid entry.
+func ParseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+		nets[i] = ipNet
+	}
+	return nets, nil
+}
@@ -0,0 +1,42 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package httpclient
+
+import (
+	"net"
+	"testing"
+)
+
+func TestPolicy_IsBlocked(t *testing.T) {
+	denyCIDRs, err := ParseCIDRs([]string{"203.0.113.0/24"})
+	if err != nil {
+		t.Fatalf("failed to parse deny CIDRs: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		allowPrivate bool
+		ip           string
+		blocked      bool
+	}{
+		{name: "loopback denied by default", ip: "127.0.0.1", blocked: true},
+		{name: "link-local denied by default", ip: "169.254.169.254", blocked: true},
+		{name: "private rfc1918 denied by default", ip: "10.0.0.5", blocked: true},
+		{name: "public address allowed", ip: "8.8.8.8", blocked: false},
+		{name: "loopback allowed with AllowPrivate", allowPrivate: true, ip: "127.0.0.1", blocked: false},
+		{name: "admin deny list always blocked", allowPrivate: true, ip: "203.0.113.1", blocked: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := Policy{AllowPrivate: tt.allowPrivate, DenyCIDRs: denyCIDRs}
+
+			got := policy.IsBlocked(net.ParseIP(tt.ip))
+			if got != tt.blocked {
+				t.Errorf("IsBlocked(%s) = %v, want %v", tt.ip, got, tt.blocked)
+			}
+		})
+	}
+}
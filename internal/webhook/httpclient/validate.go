@@ -0,0 +1,38 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package httpclient
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+)
+
+// ValidateURL rejects obviously-bad webhook targets up front, at webhook
+// create/update time. It complements (but doesn't replace) the dial-time
+// enforcement in New/controlFunc, which is also needed to catch hostnames that
+// resolve to a blocked address only at delivery time.
+func ValidateURL(rawURL string, policy Policy) error {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook url: %w", err)
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return fmt.Errorf("webhook url must use http or https, got %q", parsed.Scheme)
+	}
+
+	if parsed.Hostname() == "" {
+		return fmt.Errorf("webhook url must have a host")
+	}
+
+	// if the host is already an IP literal we can enforce the policy immediately -
+	// hostnames are re-checked at dial time once they've been resolved.
+	if ip := net.ParseIP(parsed.Hostname()); ip != nil && policy.IsBlocked(ip) {
+		return fmt.Errorf("webhook url targets a disallowed address: %s", ip)
+	}
+
+	return nil
+}
@@ -0,0 +1,218 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+// Package webhook implements the background delivery pipeline for webhooks:
+// it pulls due HookTasks, signs and sends them, and reschedules failures with
+// exponential backoff.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/harness/gitness/internal/store"
+	"github.com/harness/gitness/internal/webhook/httpclient"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Config controls the behavior of the delivery worker.
+type Config struct {
+	// PollInterval is how often the worker checks for due hook tasks.
+	PollInterval time.Duration
+
+	// BatchSize is the maximum number of due hook tasks pulled per poll.
+	BatchSize int
+
+	// MaxRetries is the maximum number of delivery attempts before a task is
+	// given up on and left as undelivered.
+	MaxRetries int
+
+	// BackoffBase is the base duration used to compute the exponential backoff
+	// between delivery attempts (attempt N waits BackoffBase * 2^(N-1)).
+	BackoffBase time.Duration
+
+	// BackoffMax caps the computed backoff duration.
+	BackoffMax time.Duration
+
+	// CleanupInterval is how often delivered hook tasks older than CleanupAge
+	// are purged. A zero value disables the cleanup routine.
+	CleanupInterval time.Duration
+
+	// CleanupAge is the minimum age of a delivered hook task before it's purged.
+	CleanupAge time.Duration
+
+	// RequestTimeout bounds a single delivery attempt.
+	RequestTimeout time.Duration
+
+	// URLPolicy is the SSRF policy deliveries are made under, before any
+	// per-webhook webhook_allow_private override is applied.
+	URLPolicy httpclient.Policy
+}
+
+// Service pulls due hook tasks and delivers them over HTTP.
+type Service struct {
+	config Config
+
+	webhookStore  store.WebhookStore
+	hookTaskStore store.HookTaskStore
+
+	listenerMu     sync.Mutex
+	listeners      map[int]chan *types.HookTask
+	nextListenerID int
+}
+
+// NewService returns a new delivery Service.
+func NewService(config Config, webhookStore store.WebhookStore, hookTaskStore store.HookTaskStore) *Service {
+	return &Service{
+		config:        config,
+		webhookStore:  webhookStore,
+		hookTaskStore: hookTaskStore,
+	}
+}
+
+// Start runs the delivery worker and the periodic cleanup routine until ctx is canceled.
+func (s *Service) Start(ctx context.Context) {
+	go s.runDeliveryLoop(ctx)
+	if s.config.CleanupInterval > 0 {
+		go s.runCleanupLoop(ctx)
+	}
+}
+
+func (s *Service) runDeliveryLoop(ctx context.Context) {
+	ticker := time.NewTicker(s.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.deliverDue(ctx); err != nil {
+				log.Ctx(ctx).Err(err).Msg("webhook: failed to deliver due hook tasks")
+			}
+		}
+	}
+}
+
+func (s *Service) runCleanupLoop(ctx context.Context) {
+	ticker := time.NewTicker(s.config.CleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			cutoff := time.Now().Add(-s.config.CleanupAge).UnixMilli()
+			n, err := s.hookTaskStore.Purge(ctx, cutoff)
+			if err != nil {
+				log.Ctx(ctx).Err(err).Msg("webhook: failed to purge delivered hook tasks")
+				continue
+			}
+			if n > 0 {
+				log.Ctx(ctx).Info().Msgf("webhook: purged %d delivered hook tasks", n)
+			}
+		}
+	}
+}
+
+func (s *Service) deliverDue(ctx context.Context) error {
+	tasks, err := s.hookTaskStore.ListUndelivered(ctx, time.Now().UnixMilli(), s.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list undelivered hook tasks: %w", err)
+	}
+
+	for _, task := range tasks {
+		if err := s.deliver(ctx, task); err != nil {
+			log.Ctx(ctx).Err(err).Msgf("webhook: failed to deliver hook task %d", task.ID)
+		}
+	}
+
+	return nil
+}
+
+// deliver attempts a single delivery of task, updating its state (attempts, response,
+// next retry time, is_delivered) regardless of outcome.
+func (s *Service) deliver(ctx context.Context, task *types.HookTask) error {
+	hook, err := s.webhookStore.Find(ctx, task.WebhookID)
+	if err != nil {
+		return fmt.Errorf("failed to find webhook %d: %w", task.WebhookID, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, task.RequestURL,
+		bytes.NewReader([]byte(task.RequestBody)))
+	if err != nil {
+		return fmt.Errorf("failed to create delivery request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(signatureHeader, "sha256="+sign(hook.Secret, []byte(task.RequestBody)))
+	if hook.AuthorizationHeader != "" {
+		req.Header.Set("Authorization", hook.AuthorizationHeader)
+	}
+	task.RequestHeaders = headersToString(req.Header)
+
+	policy := s.config.URLPolicy
+	policy.AllowPrivate = hook.AllowPrivate
+	client := httpclient.New(policy, hook.Insecure, s.config.RequestTimeout)
+
+	task.Attempts++
+
+	resp, err := client.Do(req)
+	if err != nil {
+		task.ResponseBody = err.Error()
+		return s.reschedule(ctx, task)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	task.ResponseStatusCode = resp.StatusCode
+	task.ResponseBody = string(body)
+	task.ResponseHeaders = headersToString(resp.Header)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		task.IsDelivered = true
+		task.Updated = time.Now().UnixMilli()
+		err = s.hookTaskStore.Update(ctx, task)
+		s.notify(task)
+		return err
+	}
+
+	return s.reschedule(ctx, task)
+}
+
+// reschedule records a failed attempt and, unless MaxRetries has been reached,
+// schedules the next attempt using exponential backoff.
+func (s *Service) reschedule(ctx context.Context, task *types.HookTask) error {
+	now := time.Now()
+	task.Updated = now.UnixMilli()
+
+	if task.Attempts >= s.config.MaxRetries {
+		// give up - leave the task as undelivered for operators to inspect and
+		// manually redeliver, but mark it abandoned so ListUndelivered stops
+		// selecting it for further automatic attempts.
+		task.NextDeliveredAt = 0
+		task.IsAbandoned = true
+		err := s.hookTaskStore.Update(ctx, task)
+		s.notify(task)
+		return err
+	}
+
+	backoff := s.config.BackoffBase * time.Duration(1<<uint(task.Attempts-1))
+	if backoff > s.config.BackoffMax {
+		backoff = s.config.BackoffMax
+	}
+
+	task.NextDeliveredAt = now.Add(backoff).UnixMilli()
+
+	err := s.hookTaskStore.Update(ctx, task)
+	s.notify(task)
+	return err
+}
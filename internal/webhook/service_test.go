@@ -0,0 +1,102 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/types"
+)
+
+// fakeHookTaskStore is a minimal store.HookTaskStore that only records the
+// last task passed to Update, which is all reschedule's tests need.
+type fakeHookTaskStore struct {
+	updated *types.HookTask
+}
+
+func (f *fakeHookTaskStore) Find(context.Context, int64) (*types.HookTask, error) { return nil, nil }
+func (f *fakeHookTaskStore) Create(context.Context, *types.HookTask) error        { return nil }
+
+func (f *fakeHookTaskStore) Update(_ context.Context, task *types.HookTask) error {
+	f.updated = task
+	return nil
+}
+
+func (f *fakeHookTaskStore) List(context.Context, int64, *types.HookTaskFilter) ([]*types.HookTask, error) {
+	return nil, nil
+}
+func (f *fakeHookTaskStore) Count(context.Context, int64) (int64, error) { return 0, nil }
+
+func (f *fakeHookTaskStore) ListUndelivered(context.Context, int64, int) ([]*types.HookTask, error) {
+	return nil, nil
+}
+func (f *fakeHookTaskStore) Purge(context.Context, int64) (int64, error) { return 0, nil }
+
+func TestReschedule_BacksOffUntilMaxRetries(t *testing.T) {
+	store := &fakeHookTaskStore{}
+	s := NewService(Config{
+		MaxRetries:  3,
+		BackoffBase: time.Second,
+		BackoffMax:  time.Hour,
+	}, nil, store)
+
+	task := &types.HookTask{Attempts: 1}
+	if err := s.reschedule(context.Background(), task); err != nil {
+		t.Fatalf("reschedule returned error: %v", err)
+	}
+
+	if task.IsAbandoned {
+		t.Fatalf("task should not be abandoned before MaxRetries is reached")
+	}
+	if task.NextDeliveredAt == 0 {
+		t.Fatalf("expected a non-zero next_delivered_at while retries remain")
+	}
+}
+
+func TestReschedule_CapsBackoffAtBackoffMax(t *testing.T) {
+	store := &fakeHookTaskStore{}
+	s := NewService(Config{
+		MaxRetries:  10,
+		BackoffBase: time.Second,
+		BackoffMax:  5 * time.Second,
+	}, nil, store)
+
+	task := &types.HookTask{Attempts: 9}
+	before := time.Now()
+	if err := s.reschedule(context.Background(), task); err != nil {
+		t.Fatalf("reschedule returned error: %v", err)
+	}
+
+	wait := time.Duration(task.NextDeliveredAt-before.UnixMilli()) * time.Millisecond
+	if wait > 6*time.Second {
+		t.Fatalf("expected backoff to be capped near BackoffMax, got %s", wait)
+	}
+}
+
+func TestReschedule_AbandonsAfterMaxRetries(t *testing.T) {
+	store := &fakeHookTaskStore{}
+	s := NewService(Config{
+		MaxRetries:  3,
+		BackoffBase: time.Second,
+		BackoffMax:  time.Hour,
+	}, nil, store)
+
+	task := &types.HookTask{Attempts: 3}
+	if err := s.reschedule(context.Background(), task); err != nil {
+		t.Fatalf("reschedule returned error: %v", err)
+	}
+
+	if !task.IsAbandoned {
+		t.Fatalf("expected task to be abandoned once Attempts reaches MaxRetries")
+	}
+	if task.NextDeliveredAt != 0 {
+		t.Fatalf("expected next_delivered_at to be cleared for an abandoned task")
+	}
+	if store.updated != task {
+		t.Fatalf("expected reschedule to persist the task via the store")
+	}
+}
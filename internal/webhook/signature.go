@@ -0,0 +1,22 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// signatureHeader is the HTTP header the delivered signature is sent in,
+// mirroring the "sha256=<hex>" convention used by GitHub/Gitea style webhooks.
+const signatureHeader = "X-Gitness-Signature"
+
+// sign returns the hex-encoded HMAC-SHA256 signature of body keyed with secret.
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package enum
+
+// WebhookParent defines the parent type of a webhook.
+type WebhookParent string
+
+const (
+	// WebhookParentRepo means the webhook is configured on a repository.
+	WebhookParentRepo WebhookParent = "repo"
+
+	// WebhookParentSpace means the webhook is configured on a space.
+	WebhookParentSpace WebhookParent = "space"
+)
+
+// WebhookTrigger defines the events that can trigger a webhook.
+type WebhookTrigger string
+
+const (
+	WebhookTriggerBranchCreated WebhookTrigger = "branch_created"
+	WebhookTriggerBranchUpdated WebhookTrigger = "branch_updated"
+	WebhookTriggerBranchDeleted WebhookTrigger = "branch_deleted"
+
+	WebhookTriggerTagCreated WebhookTrigger = "tag_created"
+	WebhookTriggerTagUpdated WebhookTrigger = "tag_updated"
+	WebhookTriggerTagDeleted WebhookTrigger = "tag_deleted"
+
+	WebhookTriggerPullReqCreated        WebhookTrigger = "pullreq_created"
+	WebhookTriggerPullReqReopened       WebhookTrigger = "pullreq_reopened"
+	WebhookTriggerPullReqBranchUpdated  WebhookTrigger = "pullreq_branch_updated"
+	WebhookTriggerPullReqClosed         WebhookTrigger = "pullreq_closed"
+	WebhookTriggerPullReqCommentCreated WebhookTrigger = "pullreq_comment_created"
+
+	// WebhookTriggerFork is the only repository-lifecycle trigger. Its event is
+	// built by the repo Controller.publishRepositoryForkedEvent, which has no
+	// caller, so no webhook currently receives it. There are no triggers for
+	// repository creation, deletion or transfer, published releases, or issue
+	// comments.
+	WebhookTriggerFork WebhookTrigger = "fork"
+)
+
+// triggersFromString (internal/store/database) treats unknown values as opaque
+// strings rather than rejecting them, so adding new WebhookTrigger constants here
+// never breaks parsing of trigger lists persisted by older server versions -
+// a webhook just won't fire for trigger types it was created before they existed.
+
+// HasRepositoryEvent reports whether t is fired for an event that happens on a
+// repository, as opposed to e.g. a space-level event. Used by the dispatcher to
+// decide whether a trigger is even eligible to be matched against a given event.
+func (t WebhookTrigger) HasRepositoryEvent() bool {
+	switch t {
+	case WebhookTriggerBranchCreated,
+		WebhookTriggerBranchUpdated,
+		WebhookTriggerBranchDeleted,
+		WebhookTriggerTagCreated,
+		WebhookTriggerTagUpdated,
+		WebhookTriggerTagDeleted,
+		WebhookTriggerPullReqCreated,
+		WebhookTriggerPullReqReopened,
+		WebhookTriggerPullReqBranchUpdated,
+		WebhookTriggerPullReqClosed,
+		WebhookTriggerPullReqCommentCreated,
+		WebhookTriggerFork:
+		return true
+	default:
+		return false
+	}
+}
+
+// WebhookFormat defines the payload shape a webhook delivers, so it can be pointed
+// directly at chat/collaboration tools without a translation proxy in between.
+type WebhookFormat string
+
+const (
+	// WebhookFormatGeneric delivers the canonical Gitness JSON event payload.
+	WebhookFormatGeneric WebhookFormat = "generic"
+
+	WebhookFormatSlack    WebhookFormat = "slack"
+	WebhookFormatDiscord  WebhookFormat = "discord"
+	WebhookFormatMSTeams  WebhookFormat = "msteams"
+	WebhookFormatMatrix   WebhookFormat = "matrix"
+	WebhookFormatDingtalk WebhookFormat = "dingtalk"
+	WebhookFormatFeishu   WebhookFormat = "feishu"
+)
@@ -0,0 +1,43 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package types
+
+// HookTask represents a single delivery attempt (or pending attempt) of a webhook.
+// Rows are created eagerly when an event is dispatched and updated in place as the
+// delivery worker retries them, so the full history of a webhook's deliveries can
+// be inspected even after the underlying event is long gone.
+type HookTask struct {
+	ID        int64 `json:"id"`
+	Version   int64 `json:"-"`
+	WebhookID int64 `json:"webhook_id"`
+	Created   int64 `json:"created"`
+	Updated   int64 `json:"updated"`
+
+	Trigger string `json:"trigger"`
+
+	RequestURL     string `json:"request_url"`
+	RequestHeaders string `json:"request_headers"`
+	RequestBody    string `json:"request_body"`
+
+	ResponseStatusCode int    `json:"response_status_code,omitempty"`
+	ResponseHeaders    string `json:"response_headers,omitempty"`
+	ResponseBody       string `json:"response_body,omitempty"`
+
+	Attempts        int   `json:"attempts"`
+	IsDelivered     bool  `json:"is_delivered"`
+	NextDeliveredAt int64 `json:"next_delivered_at"`
+
+	// IsAbandoned is set once Attempts has exhausted the delivery worker's
+	// MaxRetries, so the task stops being picked up by ListUndelivered even
+	// though it was never successfully delivered. A manual Redeliver is the
+	// only way to retry it after that.
+	IsAbandoned bool `json:"is_abandoned"`
+}
+
+// HookTaskFilter stores the options used to paginate and filter hook tasks.
+type HookTaskFilter struct {
+	Page int `json:"page"`
+	Size int `json:"size"`
+}
@@ -0,0 +1,48 @@
+// Copyright 2022 Harness Inc. All rights reserved.
+// Use of this source code is governed by the Polyform Free Trial License
+// that can be found in the LICENSE.md file for this repository.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// Webhook represents a webhook configured on a repo or space.
+type Webhook struct {
+	ID         int64              `json:"id"`
+	Version    int64              `json:"-"`
+	ParentType enum.WebhookParent `json:"parent_type"`
+	ParentID   int64              `json:"parent_id"`
+	CreatedBy  int64              `json:"created_by"`
+	Created    int64              `json:"created"`
+	Updated    int64              `json:"updated"`
+
+	URL      string                `json:"url"`
+	Secret   string                `json:"-"`
+	Enabled  bool                  `json:"enabled"`
+	Insecure bool                  `json:"insecure"`
+	Triggers []enum.WebhookTrigger `json:"triggers"`
+
+	// Format controls the shape of the JSON payload delivered to URL, allowing a
+	// webhook to target chat tools (Slack, Discord, ...) directly.
+	Format enum.WebhookFormat `json:"format"`
+
+	// AuthorizationHeader holds the static Authorization header value sent with every
+	// delivery. It's stored encrypted at rest and is never returned over the API -
+	// HasAuthorizationHeader communicates its presence instead.
+	AuthorizationHeader    string `json:"-"`
+	HasAuthorizationHeader bool   `json:"has_secret_header"`
+
+	// AllowPrivate lets the webhook target loopback/link-local/private addresses
+	// that are otherwise rejected to prevent SSRF. Only settable by site-admins.
+	AllowPrivate bool `json:"allow_private"`
+}
+
+// WebhookFilter stores the options used to paginate and filter webhooks.
+type WebhookFilter struct {
+	Page int `json:"page"`
+	Size int `json:"size"`
+
+	// Formats restricts the list to webhooks using one of the given payload formats.
+	// An empty slice doesn't filter by format.
+	Formats []enum.WebhookFormat `json:"format"`
+}